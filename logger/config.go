@@ -1,25 +1,144 @@
 package logger
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 type LogConfig struct {
-	TimestampFormat string `xml:"timestampFormat"`
-	Pattern         string `xml:"pattern"`
-	Level           string `xml:"level"`
+	TimestampFormat    string   `xml:"timestampFormat" json:"timestampFormat" yaml:"timestampFormat"`
+	Pattern            string   `xml:"pattern" json:"pattern" yaml:"pattern"`
+	Level              string   `xml:"level" json:"level" yaml:"level"`
+	RedactKeys         []string `xml:"redactKeys>key" json:"redactKeys" yaml:"redactKeys"`
+	EscapeNewlines     bool     `xml:"escapeNewlines" json:"escapeNewlines" yaml:"escapeNewlines"`
+	FunctionFormat     string   `xml:"functionFormat" json:"functionFormat" yaml:"functionFormat"`
+	TimeZone           string   `xml:"timeZone" json:"timeZone" yaml:"timeZone"`
+	IncludeGoroutineID bool     `xml:"includeGoroutineId" json:"includeGoroutineId" yaml:"includeGoroutineId"`
+	IncludeHostname    bool     `xml:"includeHostname" json:"includeHostname" yaml:"includeHostname"`
+	IncludePID         bool     `xml:"includePid" json:"includePid" yaml:"includePid"`
+	ServiceName        string   `xml:"serviceName" json:"serviceName" yaml:"serviceName"`
+	MaxMessageBytes    int      `xml:"maxMessageBytes" json:"maxMessageBytes" yaml:"maxMessageBytes"`
+	MaxFieldBytes      int      `xml:"maxFieldBytes" json:"maxFieldBytes" yaml:"maxFieldBytes"`
+	IncludeDeadline    bool     `xml:"includeDeadline" json:"includeDeadline" yaml:"includeDeadline"`
 }
 
 func LoadLogConfig(path string) (*LogConfig, error) {
+	return loadLogConfigAs(path, "xml")
+}
+
+func LoadLogConfigJSON(path string) (*LogConfig, error) {
+	return loadLogConfigAs(path, "json")
+}
+
+func LoadLogConfigYAML(path string) (*LogConfig, error) {
+	return loadLogConfigAs(path, "yaml")
+}
+
+func loadLogConfigAs(path string, format string) (*LogConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return LoadLogConfigFromBytes(data, format)
+}
+
+// LoadLogConfigFromBytes parses data as xml, json or yaml and validates the
+// result, so configs embedded in larger files can be loaded directly.
+func LoadLogConfigFromBytes(data []byte, format string) (*LogConfig, error) {
 	var cfg LogConfig
-	err = xml.Unmarshal(data, &cfg)
+	var err error
+	switch strings.ToLower(format) {
+	case "xml":
+		err = xml.Unmarshal(data, &cfg)
+	case "json":
+		err = json.Unmarshal(data, &cfg)
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &cfg)
+	default:
+		return nil, fmt.Errorf("unsupported log config format %q", format)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid log config: %w", err)
+	}
 	return &cfg, nil
 }
+
+func (c *LogConfig) applyDefaults() {
+	if c.TimestampFormat == "" {
+		c.TimestampFormat = defaultTimestampFormat
+	}
+	if c.Pattern == "" {
+		c.Pattern = defaultPattern
+	}
+	if c.Level == "" {
+		c.Level = defaultLevel
+	}
+}
+
+// Validate checks the level, pattern and time zone, naming the offending
+// field in the error. Empty fields are valid because Init fills them with
+// defaults.
+func (c *LogConfig) Validate() error {
+	if c.Level != "" {
+		if _, err := logrus.ParseLevel(c.Level); err != nil {
+			return fmt.Errorf("level: %w", err)
+		}
+	}
+	if err := ValidatePattern(c.Pattern); patternUnusable(c.Pattern, err) {
+		return fmt.Errorf("pattern: %w", err)
+	}
+	if _, err := loadLocation(c.TimeZone); err != nil {
+		return fmt.Errorf("timeZone: %w", err)
+	}
+	return nil
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	return time.LoadLocation(name)
+}
+
+func loadLogConfigFile(path string) (*LogConfig, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadLogConfigJSON(path)
+	case ".yaml", ".yml":
+		return LoadLogConfigYAML(path)
+	default:
+		return LoadLogConfig(path)
+	}
+}
+
+// loadLogConfigFromDir loads the first config file found in dir, trying
+// log-config.json, log-config.yaml and log-config.xml in that order.
+func loadLogConfigFromDir(dir string) (*LogConfig, error) {
+	loaders := []struct {
+		name string
+		load func(string) (*LogConfig, error)
+	}{
+		{"log-config.json", LoadLogConfigJSON},
+		{"log-config.yaml", LoadLogConfigYAML},
+		{"log-config.xml", LoadLogConfig},
+	}
+	for _, l := range loaders {
+		cfg, err := l.load(filepath.Join(dir, l.name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		return cfg, err
+	}
+	return nil, os.ErrNotExist
+}
@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLogConfigFormats(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		load    func(string) (*LogConfig, error)
+	}{
+		{
+			name:    "xml",
+			file:    "log-config.xml",
+			content: `<logConfig><timestampFormat>15:04</timestampFormat><pattern>%level% %message%</pattern><level>debug</level></logConfig>`,
+			load:    LoadLogConfig,
+		},
+		{
+			name:    "json",
+			file:    "log-config.json",
+			content: `{"timestampFormat":"15:04","pattern":"%level% %message%","level":"debug"}`,
+			load:    LoadLogConfigJSON,
+		},
+		{
+			name:    "yaml",
+			file:    "log-config.yaml",
+			content: "timestampFormat: '15:04'\npattern: '%level% %message%'\nlevel: debug\n",
+			load:    LoadLogConfigYAML,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeFile(t, path, tt.content)
+			cfg, err := tt.load(path)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			want := LogConfig{TimestampFormat: "15:04", Pattern: "%level% %message%", Level: "debug"}
+			if cfg.TimestampFormat != want.TimestampFormat || cfg.Pattern != want.Pattern || cfg.Level != want.Level {
+				t.Fatalf("got %+v, want %+v", *cfg, want)
+			}
+		})
+	}
+}
+
+func TestLoadLogConfigMalformed(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		load    func(string) (*LogConfig, error)
+	}{
+		{"xml", "bad.xml", `<logConfig><level>debug</logConfig>`, LoadLogConfig},
+		{"json", "bad.json", `{"level": "debug"`, LoadLogConfigJSON},
+		{"yaml", "bad.yaml", "level: [debug\n", LoadLogConfigYAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			writeFile(t, path, tt.content)
+			if _, err := tt.load(path); err == nil {
+				t.Fatal("expected an error for a malformed file")
+			}
+		})
+	}
+}
+
+func TestInitPrefersJSONAndFillsDefaults(t *testing.T) {
+	resetLogger(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "log-config.json"), `{"level":"debug"}`)
+	writeFile(t, filepath.Join(dir, "log-config.xml"), `<logConfig><level>error</level></logConfig>`)
+	chdir(t, dir)
+
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+	Log.Debug("hello")
+
+	out := buf.String()
+	if !strings.Contains(out, "| DEBUG |") || !strings.Contains(out, "| hello") {
+		t.Fatalf("expected default pattern with debug entry, got %q", out)
+	}
+}
+
+func TestInitFallsBackOnMalformedConfig(t *testing.T) {
+	resetLogger(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "log-config.yaml"), "level: [debug\n")
+	chdir(t, dir)
+
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+	Log.Debug("hidden")
+	Log.Info("shown")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") || !strings.Contains(out, "| INFO |") {
+		t.Fatalf("expected defaults after malformed config, got %q", out)
+	}
+}
+
+func TestInitWithConfigOutsideWorkingDirectory(t *testing.T) {
+	resetLogger(t)
+	path := filepath.Join(t.TempDir(), "nested", "service.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, `{"pattern":"%level% %message%","level":"warn"}`)
+
+	if err := InitWithConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+	Log.Info("hidden")
+	Log.Warn("shown")
+
+	if got := buf.String(); got != "WARNING shown\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestInitWithConfigFormat(t *testing.T) {
+	resetLogger(t)
+	path := filepath.Join(t.TempDir(), "logging.conf")
+	writeFile(t, path, "pattern: '%level% %message%'\nlevel: error\n")
+
+	if err := InitWithOptions(WithConfigPath(path), WithConfigFormat("yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if Log.GetLevel() != logrus.ErrorLevel {
+		t.Fatalf("level = %v, want error", Log.GetLevel())
+	}
+}
+
+func TestInitWithMissingConfigReturnsError(t *testing.T) {
+	resetLogger(t)
+	dir := t.TempDir()
+	err := InitWithConfig(filepath.Join(dir, "missing.xml"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("err = %v, want os.ErrNotExist", err)
+	}
+	if Log == nil {
+		t.Fatal("Log should fall back to defaults")
+	}
+
+	path := filepath.Join(dir, "log-config.json")
+	writeFile(t, path, `{"level":"debug"}`)
+	if err := InitWithConfig(path); err != nil {
+		t.Fatalf("retry with a corrected path = %v, want nil", err)
+	}
+	if Log.GetLevel() != logrus.DebugLevel {
+		t.Fatalf("level = %v, want debug from the corrected config", Log.GetLevel())
+	}
+}
+
+func TestInitTwiceWithConfigReturnsErrAlreadyInitialized(t *testing.T) {
+	resetLogger(t)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "log-config.json")
+	writeFile(t, path, `{"level":"debug"}`)
+
+	if err := InitWithConfig(path); !errors.Is(err, ErrAlreadyInitialized) {
+		t.Fatalf("err = %v, want ErrAlreadyInitialized", err)
+	}
+	if err := Init(); err != nil {
+		t.Fatalf("Init after Init = %v, want nil", err)
+	}
+	if Log.GetLevel() != logrus.InfoLevel {
+		t.Fatalf("level = %v, want the first config to stay in effect", Log.GetLevel())
+	}
+}
+
+func TestLoadLogConfigFromBytes(t *testing.T) {
+	embedded := "pattern: '%level% %message%'\nlevel: debug\nredactKeys: [password, token]\n"
+	for _, format := range []string{"yaml", "YML"} {
+		cfg, err := LoadLogConfigFromBytes([]byte(embedded), format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if cfg.Level != "debug" || cfg.Pattern != "%level% %message%" || len(cfg.RedactKeys) != 2 {
+			t.Fatalf("%s: got %+v", format, *cfg)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("%s: Validate() = %v", format, err)
+		}
+	}
+
+	if _, err := LoadLogConfigFromBytes([]byte(embedded), "toml"); err == nil || !strings.Contains(err.Error(), `"toml"`) {
+		t.Fatalf("err = %v, want an unsupported format error", err)
+	}
+}
+
+func TestLogConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     LogConfig
+		wantErr string
+		is      error
+	}{
+		{"valid", LogConfig{Level: "warn", Pattern: "%level% %message%"}, "", nil},
+		{"unknown placeholder is allowed", LogConfig{Level: "info", Pattern: "%tenant% %message%"}, "", nil},
+		{"invalid level", LogConfig{Level: "loud", Pattern: "%message%"}, "level: ", nil},
+		{"unbalanced pattern", LogConfig{Level: "info", Pattern: "%level %message%"}, "pattern: ", ErrUnbalancedPattern},
+		{"invalid time zone", LogConfig{Level: "info", Pattern: "%message%", TimeZone: "Mars/Olympus"}, "timeZone: ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() = %v, want prefix %q", err, tt.wantErr)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Fatalf("Validate() = %v, want it to wrap %v", err, tt.is)
+			}
+		})
+	}
+}
+
+func TestLoadLogConfigFromBytesPartialConfig(t *testing.T) {
+	cfg, err := LoadLogConfigFromBytes([]byte(`{"pattern":"%message%"}`), "json")
+	if err != nil {
+		t.Fatalf("partial config rejected: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil for a partial config", err)
+	}
+}
+
+func TestLoadLogConfigFromBytesValidates(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		field  string
+	}{
+		{"invalid level", "level: loud\n", "yaml", "level: "},
+		{"unbalanced pattern", `{"pattern":"%level %message%"}`, "json", "pattern: "},
+		{"invalid time zone", `<logConfig><timeZone>Mars/Olympus</timeZone></logConfig>`, "xml", "timeZone: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadLogConfigFromBytes([]byte(tt.data), tt.format)
+			if err == nil || !strings.Contains(err.Error(), tt.field) {
+				t.Fatalf("err = %v, want it to name %q", err, strings.TrimSuffix(tt.field, ": "))
+			}
+		})
+	}
+}
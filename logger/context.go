@@ -4,19 +4,81 @@ import (
 	"context"
 	"fmt"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
+const requestIDKey = "requestId"
+
+type fieldsKey struct{}
+
+type entryKey struct{}
+
+func InjectRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
 func GetRequestID(ctx context.Context) string {
-	v := ctx.Value("requestId")
+	if ctx == nil {
+		return "null"
+	}
+	v := ctx.Value(requestIDKey)
 	if v == nil {
 		return "null"
 	}
 	return fmt.Sprint(v)
 }
 
+func InjectFields(ctx context.Context, fields map[string]any) context.Context {
+	merged := FieldsFromContext(ctx)
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+func FieldsFromContext(ctx context.Context) map[string]any {
+	fields := map[string]any{}
+	if ctx == nil {
+		return fields
+	}
+	if stored, ok := ctx.Value(fieldsKey{}).(map[string]any); ok {
+		for k, v := range stored {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
 func WithContext(ctx context.Context) *logrus.Entry {
 	requestID := GetRequestID(ctx)
-	return Log.WithFields(logrus.Fields{
-		"requestId": requestID,
-	})
+	fields := logrus.Fields(FieldsFromContext(ctx))
+	fields[requestIDKey] = requestID
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields["traceId"] = spanCtx.TraceID().String()
+		fields["spanId"] = spanCtx.SpanID().String()
+	}
+	if includeDeadline.Load() && ctx != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			remaining := time.Until(deadline).Milliseconds()
+			fields["deadlineRemainingMs"] = remaining
+			if remaining <= 0 {
+				fields["deadlineExceeded"] = true
+			}
+		}
+	}
+	return Log.WithFields(fields)
+}
+
+func IntoContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(entryKey{}).(*logrus.Entry); ok && entry != nil {
+			return entry
+		}
+	}
+	return WithContext(ctx)
 }
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initTestLogger(t *testing.T) {
+	t.Helper()
+	resetLogger(t)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t)
+}
+
+func TestWithContextAddsTraceAndSpanIDs(t *testing.T) {
+	initTestLogger(t)
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:     trace.SpanID{0x0a, 0x0b},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	entry := WithContext(ctx)
+	if got := entry.Data["traceId"]; got != spanCtx.TraceID().String() {
+		t.Fatalf("traceId = %v, want %s", got, spanCtx.TraceID())
+	}
+	if got := entry.Data["spanId"]; got != spanCtx.SpanID().String() {
+		t.Fatalf("spanId = %v, want %s", got, spanCtx.SpanID())
+	}
+}
+
+func TestWithContextOmitsTraceFieldsWithoutSpan(t *testing.T) {
+	initTestLogger(t)
+	entry := WithContext(context.Background())
+	if _, ok := entry.Data["traceId"]; ok {
+		t.Fatal("traceId should be omitted without a span context")
+	}
+	if _, ok := entry.Data["spanId"]; ok {
+		t.Fatal("spanId should be omitted without a span context")
+	}
+}
+
+func TestWithContextNilContext(t *testing.T) {
+	initTestLogger(t)
+	buf := captureOutput(t)
+
+	//lint:ignore SA1012 a nil context is exactly what is under test
+	WithContext(nil).Info("no context")
+
+	if got := buf.String(); !strings.Contains(got, "| null |") || !strings.Contains(got, "no context") {
+		t.Fatalf("unexpected output %q", got)
+	}
+	//lint:ignore SA1012 a nil context is exactly what is under test
+	if id := GetRequestID(nil); id != "null" {
+		t.Fatalf("GetRequestID(nil) = %q, want null", id)
+	}
+}
+
+func TestInjectFieldsMergesStackedInjections(t *testing.T) {
+	initTestLogger(t)
+	parent := InjectFields(context.Background(), map[string]any{"tenantId": "acme", "userId": "u1"})
+	child := InjectFields(parent, map[string]any{"userId": "u2", "role": "admin"})
+
+	want := map[string]any{"tenantId": "acme", "userId": "u2", "role": "admin"}
+	entry := WithContext(child)
+	for k, v := range want {
+		if got := entry.Data[k]; got != v {
+			t.Fatalf("%s = %v, want %v", k, got, v)
+		}
+	}
+	if got := FieldsFromContext(parent)["userId"]; got != "u1" {
+		t.Fatalf("parent userId = %v, want u1", got)
+	}
+	if _, ok := FieldsFromContext(parent)["role"]; ok {
+		t.Fatal("child injection leaked into the parent context")
+	}
+}
+
+func TestFromContextReturnsStoredEntry(t *testing.T) {
+	initTestLogger(t)
+	entry := Log.WithField("component", "billing")
+	ctx := IntoContext(context.Background(), entry)
+
+	if got := FromContext(ctx); got != entry {
+		t.Fatalf("FromContext returned %p, want the stored entry %p", got, entry)
+	}
+}
+
+func TestFromContextFallsBackToWithContext(t *testing.T) {
+	initTestLogger(t)
+	ctx := InjectRequestID(context.Background(), "req-9")
+
+	entry := FromContext(ctx)
+	if entry == nil {
+		t.Fatal("FromContext returned nil")
+	}
+	if got := entry.Data[requestIDKey]; got != "req-9" {
+		t.Fatalf("requestId = %v, want req-9", got)
+	}
+}
+
+func initDeadlineLogger(t *testing.T) {
+	t.Helper()
+	resetLogger(t)
+	if err := InitWithOptions(WithLogConfig(&LogConfig{IncludeDeadline: true})); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t)
+}
+
+func TestWithContextAddsDeadlineRemaining(t *testing.T) {
+	initDeadlineLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	entry := WithContext(ctx)
+	remaining, ok := entry.Data["deadlineRemainingMs"].(int64)
+	if !ok || remaining <= 55000 || remaining > 60000 {
+		t.Fatalf("deadlineRemainingMs = %v, want close to 60000", entry.Data["deadlineRemainingMs"])
+	}
+	if _, ok := entry.Data["deadlineExceeded"]; ok {
+		t.Fatal("deadlineExceeded should be omitted before the deadline")
+	}
+}
+
+func TestWithContextFlagsExceededDeadline(t *testing.T) {
+	initDeadlineLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	entry := WithContext(ctx)
+	if remaining, ok := entry.Data["deadlineRemainingMs"].(int64); !ok || remaining > 0 {
+		t.Fatalf("deadlineRemainingMs = %v, want a non-positive value", entry.Data["deadlineRemainingMs"])
+	}
+	if got := entry.Data["deadlineExceeded"]; got != true {
+		t.Fatalf("deadlineExceeded = %v, want true", got)
+	}
+}
+
+func TestWithContextOmitsDeadlineFields(t *testing.T) {
+	initDeadlineLogger(t)
+	if _, ok := WithContext(context.Background()).Data["deadlineRemainingMs"]; ok {
+		t.Fatal("deadlineRemainingMs should be omitted without a deadline")
+	}
+
+	initTestLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := WithContext(ctx).Data["deadlineRemainingMs"]; ok {
+		t.Fatal("deadlineRemainingMs should be omitted unless includeDeadline is set")
+	}
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFatalFlushesAndRunsExitHooks(t *testing.T) {
+	if dir := os.Getenv("GO_LOGGER_FATAL_DIR"); dir != "" {
+		if err := Init(); err != nil {
+			os.Exit(2)
+		}
+		f, err := os.Create(filepath.Join(dir, "app.log"))
+		if err != nil {
+			os.Exit(2)
+		}
+		Log.SetOutput(f)
+		RegisterExitHook(func() {
+			_ = os.WriteFile(filepath.Join(dir, "hook"), []byte("ran"), 0o644)
+		})
+		Log.Info("first line")
+		Log.Fatal("last line")
+		return
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalFlushesAndRunsExitHooks$")
+	cmd.Env = append(os.Environ(), "GO_LOGGER_FATAL_DIR="+dir)
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit code 1, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if last := lines[len(lines)-1]; !strings.Contains(last, "| FATAL |") || !strings.Contains(last, "last line") {
+		t.Fatalf("last line on disk = %q", last)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "hook")); err != nil {
+		t.Fatalf("exit hook did not run: %v", err)
+	}
+}
@@ -1,11 +1,17 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 type FunctionNameFormatter interface {
@@ -22,6 +28,35 @@ func (f *DefaultFunctionNameFormatter) Format(fullName string) string {
 	return fullName
 }
 
+type FullFunctionNameFormatter struct{}
+
+func (f *FullFunctionNameFormatter) Format(fullName string) string {
+	return fullName
+}
+
+type PackageOnlyFunctionNameFormatter struct{}
+
+func (f *PackageOnlyFunctionNameFormatter) Format(fullName string) string {
+	slash := strings.LastIndex(fullName, "/")
+	if dot := strings.Index(fullName[slash+1:], "."); dot >= 0 {
+		return fullName[:slash+1+dot]
+	}
+	return fullName
+}
+
+func functionNameFormatterFor(format string) FunctionNameFormatter {
+	switch strings.ToLower(format) {
+	case "full":
+		return &FullFunctionNameFormatter{}
+	case "package":
+		return &PackageOnlyFunctionNameFormatter{}
+	case "short":
+		return &DefaultFunctionNameFormatter{}
+	default:
+		return GetFunctionNameFormatter()
+	}
+}
+
 type MessageFormater interface {
 	Format(message string) string
 }
@@ -33,18 +68,42 @@ func (d *DefaultMessageFormater) Format(message string) string {
 	return message
 }
 
+const RedactedValue = "***REDACTED***"
+
+const TruncatedSuffix = "...(truncated)"
+
 type DynamicFormatter struct {
 	Pattern               string
 	TimestampFormat       string
 	MsgFormatter          MessageFormater
 	FunctionNameFormatter FunctionNameFormatter
+	RedactKeys            []string
+	EscapeNewlines        bool
+	Location              *time.Location
+	MaxMessageBytes       int
+	MaxFieldBytes         int
 }
 
+var newlineEscaper = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`)
+
 func (f *DynamicFormatter) Format(entry *logrus.Entry) ([]byte, error) {
-	timestamp := entry.Time.Format(f.TimestampFormat)
+	entryTime := entry.Time
+	if f.Location != nil {
+		entryTime = entryTime.In(f.Location)
+	}
+	timestamp := entryTime.Format(f.TimestampFormat)
 	level := strings.ToUpper(entry.Level.String())
 
-	message := f.MsgFormatter.Format(entry.Message)
+	msgFormatter := f.MsgFormatter
+	if msgFormatter == nil {
+		msgFormatter = &DefaultMessageFormater{}
+	}
+	functionNameFormatter := f.FunctionNameFormatter
+	if functionNameFormatter == nil {
+		functionNameFormatter = &DefaultFunctionNameFormatter{}
+	}
+
+	message := f.escape(truncate(msgFormatter.Format(entry.Message), f.MaxMessageBytes))
 
 	file := "???"
 	line := 0
@@ -52,32 +111,189 @@ func (f *DynamicFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	if entry.Caller != nil {
 		file = path.Base(entry.Caller.File)
 		line = entry.Caller.Line
-		function = f.FunctionNameFormatter.Format(entry.Caller.Function)
+		function = functionNameFormatter.Format(entry.Caller.Function)
 	}
 
-	out := f.Pattern
-	out = strings.ReplaceAll(out, "%timestamp%", timestamp)
-	out = strings.ReplaceAll(out, "%level%", level)
-	out = strings.ReplaceAll(out, "%file%", file)
-	out = strings.ReplaceAll(out, "%line%", fmt.Sprintf("%d", line))
-	out = strings.ReplaceAll(out, "%function%", function)
-	out = strings.ReplaceAll(out, "%message%", message)
+	fields := ""
+	if strings.Contains(f.Pattern, "%fields%") {
+		fields = f.renderFields(entry.Data)
+	}
+	values := []string{
+		"%timestamp%", timestamp,
+		"%level%", level,
+		"%file%", file,
+		"%line%", strconv.Itoa(line),
+		"%function%", function,
+		"%message%", message,
+		"%fields%", fields,
+	}
 	for _, k := range extractPlaceholders(f.Pattern) {
-		placeholder := "%" + k + "%"
-		value, ok := entry.Data[k]
-		if !ok || value == nil {
-			out = strings.ReplaceAll(out, placeholder, "null")
-		} else {
-			out = strings.ReplaceAll(out, placeholder, fmt.Sprint(value))
+		if !builtinPlaceholders[k] {
+			values = append(values, "%"+k+"%", f.escape(f.renderField(k, entry.Data)))
 		}
 	}
-
+	out := strings.NewReplacer(values...).Replace(f.Pattern)
 	return []byte(out + "\n"), nil
 }
 
+func (f *DynamicFormatter) renderField(key string, data logrus.Fields) string {
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "null"
+	}
+	if f.isRedacted(key) {
+		return RedactedValue
+	}
+	if lazy, ok := value.(LazyValue); ok {
+		value = lazy.Value()
+		if value == nil {
+			return "null"
+		}
+	}
+	return truncate(fmt.Sprint(value), f.MaxFieldBytes)
+}
+
+// truncate cuts s to at most max bytes without splitting a rune and marks
+// the cut with TruncatedSuffix. A non-positive max disables truncation.
+func truncate(s string, max int) string {
+	if max <= 0 || len(s) <= max {
+		return s
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + TruncatedSuffix
+}
+
+func (f *DynamicFormatter) renderFields(data logrus.Fields) string {
+	rendered := map[string]bool{"logger": true, requestIDKey: true}
+	for _, k := range extractPlaceholders(f.Pattern) {
+		rendered[k] = true
+	}
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		if !rendered[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		value := f.escape(f.renderField(k, data))
+		if value == "" || strings.ContainsAny(value, " =\"") {
+			value = strconv.Quote(value)
+		}
+		pairs = append(pairs, k+"="+value)
+	}
+	return strings.Join(pairs, " ")
+}
+
+func (f *DynamicFormatter) escape(s string) string {
+	if !f.EscapeNewlines {
+		return s
+	}
+	return newlineEscaper.Replace(s)
+}
+
+func (f *DynamicFormatter) isRedacted(key string) bool {
+	for _, k := range f.RedactKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRedacted reports whether key is one of the active formatter's redact
+// keys, so values logged outside the formatter can be masked up front.
+func IsRedacted(key string) bool {
+	f := activeFormatter.Load()
+	return f != nil && f.isRedacted(key)
+}
+
+// builtinPlaceholders are rendered from the entry itself rather than from
+// its fields.
+var builtinPlaceholders = map[string]bool{
+	"timestamp": true,
+	"level":     true,
+	"file":      true,
+	"line":      true,
+	"function":  true,
+	"message":   true,
+	"fields":    true,
+}
+
+var knownPlaceholders = map[string]bool{
+	"timestamp": true,
+	"level":     true,
+	"file":      true,
+	"line":      true,
+	"function":  true,
+	"message":   true,
+	"fields":    true,
+	"requestId": true,
+	"traceId":   true,
+	"spanId":    true,
+	"logger":    true,
+	"panic":     true,
+	"stack":     true,
+	"goroutine": true,
+	"hostname":  true,
+	"pid":       true,
+	"service":   true,
+}
+
+var placeholdersMu sync.RWMutex
+
+var (
+	ErrUnbalancedPattern  = errors.New("unbalanced % in pattern")
+	ErrUnknownPlaceholder = errors.New("unknown placeholders in pattern")
+)
+
+func RegisterPlaceholder(name string) {
+	placeholdersMu.Lock()
+	defer placeholdersMu.Unlock()
+	knownPlaceholders[name] = true
+}
+
+// ValidatePattern reports an unbalanced % (ErrUnbalancedPattern) or tokens
+// that are neither built-in nor registered (ErrUnknownPlaceholder). Unknown
+// tokens still render from entry fields, so callers may treat them as a
+// warning only.
+func ValidatePattern(pattern string) error {
+	if strings.Count(pattern, "%")%2 != 0 {
+		return ErrUnbalancedPattern
+	}
+	var unknown []string
+	placeholdersMu.RLock()
+	for _, k := range extractPlaceholders(pattern) {
+		if !knownPlaceholders[k] {
+			unknown = append(unknown, "%"+k+"%")
+		}
+	}
+	placeholdersMu.RUnlock()
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownPlaceholder, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// patternUnusable reports whether a pattern with the given ValidatePattern
+// error should be replaced by the default: it is unbalanced, or it has
+// unknown tokens and no %message%, which usually means a misspelling that
+// would drop every message.
+func patternUnusable(pattern string, err error) bool {
+	return errors.Is(err, ErrUnbalancedPattern) ||
+		errors.Is(err, ErrUnknownPlaceholder) && !strings.Contains(pattern, "%message%")
+}
+
+var placeholderRegexp = regexp.MustCompile(`%([a-zA-Z0-9_]+)%`)
+
 func extractPlaceholders(pattern string) []string {
-	re := regexp.MustCompile(`%([a-zA-Z0-9_]+)%`)
-	matches := re.FindAllStringSubmatch(pattern, -1)
+	matches := placeholderRegexp.FindAllStringSubmatch(pattern, -1)
 
 	var keys []string
 	for _, match := range matches {
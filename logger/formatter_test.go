@@ -0,0 +1,360 @@
+package logger
+
+import (
+	"errors"
+	"github.com/sirupsen/logrus"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata"
+	"unicode/utf8"
+)
+
+func TestValidatePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr error
+	}{
+		{"default", defaultPattern, nil},
+		{"fields", "%level% %message% %fields%", nil},
+		{"no placeholders", "plain text", nil},
+		{"unknown", "%level% %mesage%", ErrUnknownPlaceholder},
+		{"unbalanced", "%level% %message", ErrUnbalancedPattern},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePattern(tt.pattern)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePatternListsUnknownTokens(t *testing.T) {
+	err := ValidatePattern("%mesage% %levle%")
+	if err == nil || !strings.Contains(err.Error(), "%mesage%") || !strings.Contains(err.Error(), "%levle%") {
+		t.Fatalf("error should list both unknown tokens, got %v", err)
+	}
+}
+
+func registerTestPlaceholder(t *testing.T, name string) {
+	t.Helper()
+	RegisterPlaceholder(name)
+	t.Cleanup(func() {
+		placeholdersMu.Lock()
+		delete(knownPlaceholders, name)
+		placeholdersMu.Unlock()
+	})
+}
+
+func TestRegisterPlaceholder(t *testing.T) {
+	registerTestPlaceholder(t, "tenantId")
+	if err := ValidatePattern("%tenantId% %message%"); err != nil {
+		t.Fatalf("registered placeholder rejected: %v", err)
+	}
+}
+
+func TestRegisterPlaceholderConcurrentWithValidate(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registerTestPlaceholder(t, "concurrent")
+		}()
+		go func() {
+			defer wg.Done()
+			_ = ValidatePattern("%concurrent%")
+		}()
+	}
+	wg.Wait()
+}
+
+func initWithPattern(t *testing.T, pattern string) {
+	t.Helper()
+	resetLogger(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "log-config.json"), `{"pattern":"`+pattern+`"}`)
+	chdir(t, dir)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInitKeepsPatternWithUnknownPlaceholder(t *testing.T) {
+	initWithPattern(t, "%userId% | %message%")
+	buf := captureOutput(t)
+	Log.WithField("userId", 7).Info("hello")
+
+	if got := buf.String(); got != "7 | hello\n" {
+		t.Fatalf("got %q, want custom pattern to be kept", got)
+	}
+}
+
+func TestInitFallsBackOnUnbalancedPattern(t *testing.T) {
+	initWithPattern(t, "%level | %message%")
+	buf := captureOutput(t)
+	Log.Info("hello")
+
+	if got := buf.String(); !strings.Contains(got, "| INFO |") {
+		t.Fatalf("got %q, want default pattern", got)
+	}
+}
+
+func TestInitFallsBackOnMisspelledMessage(t *testing.T) {
+	initWithPattern(t, "%level% %mesage%")
+	buf := captureOutput(t)
+	Log.Info("hello")
+
+	if got := buf.String(); !strings.Contains(got, "| INFO |") || !strings.Contains(got, "hello") {
+		t.Fatalf("got %q, want default pattern keeping the message", got)
+	}
+	err := (&LogConfig{Level: "info", Pattern: "%level% %mesage%"}).Validate()
+	if !errors.Is(err, ErrUnknownPlaceholder) {
+		t.Fatalf("Validate() = %v, want ErrUnknownPlaceholder", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"disabled", "hello", 0, "hello"},
+		{"within limit", "hello", 5, "hello"},
+		{"ascii", "hello world", 5, "hello" + TruncatedSuffix},
+		{"cut inside rune", "héllo", 2, "h" + TruncatedSuffix},
+		{"cut after rune", "héllo", 3, "hé" + TruncatedSuffix},
+		{"cjk", "日本語テキスト", 7, "日本" + TruncatedSuffix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.in, tt.max)
+			if got != tt.want {
+				t.Fatalf("truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Fatalf("truncate(%q, %d) produced invalid UTF-8", tt.in, tt.max)
+			}
+		})
+	}
+}
+
+func TestFormatTruncatesMessageAndFields(t *testing.T) {
+	f := &DynamicFormatter{
+		Pattern:               "%message% | %body%",
+		MsgFormatter:          &DefaultMessageFormater{},
+		FunctionNameFormatter: &DefaultFunctionNameFormatter{},
+		MaxMessageBytes:       8,
+		MaxFieldBytes:         4,
+	}
+	out, err := f.Format(&logrus.Entry{
+		Message: strings.Repeat("x", 20),
+		Data:    logrus.Fields{"body": "ééééé"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "xxxxxxxx" + TruncatedSuffix + " | éé" + TruncatedSuffix + "\n"
+	if got := string(out); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func formatEntry(t *testing.T, f *DynamicFormatter, entry *logrus.Entry) string {
+	t.Helper()
+	out, err := f.Format(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestEscapeNewlines(t *testing.T) {
+	entry := &logrus.Entry{
+		Message: "first\nsecond\tthird",
+		Data:    logrus.Fields{"detail": "a\r\nb"},
+	}
+
+	escaped := formatEntry(t, &DynamicFormatter{Pattern: "%message% | %detail%", EscapeNewlines: true}, entry)
+	if want := `first\nsecond\tthird | a\r\nb` + "\n"; escaped != want {
+		t.Fatalf("got %q, want %q", escaped, want)
+	}
+	if strings.Count(escaped, "\n") != 1 {
+		t.Fatalf("escaped output spans several lines: %q", escaped)
+	}
+
+	raw := formatEntry(t, &DynamicFormatter{Pattern: "%message% | %detail%"}, entry)
+	if want := "first\nsecond\tthird | a\r\nb\n"; raw != want {
+		t.Fatalf("got %q, want raw newlines preserved %q", raw, want)
+	}
+}
+
+func TestFieldsPlaceholder(t *testing.T) {
+	f := &DynamicFormatter{Pattern: "%message% | %userId% | %fields%"}
+	entry := &logrus.Entry{
+		Message: "hello",
+		Data: logrus.Fields{
+			"tenant":    "acme corp",
+			"attempt":   2,
+			"userId":    7,
+			"requestId": "req-1",
+			"logger":    "billing",
+		},
+	}
+
+	want := `hello | 7 | attempt=2 tenant="acme corp"` + "\n"
+	if got := formatEntry(t, f, entry); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFieldsNotRenderedWithoutPlaceholder(t *testing.T) {
+	calls := 0
+	entry := &logrus.Entry{
+		Message: "hello",
+		Data: logrus.Fields{"dump": Lazy(func() any {
+			calls++
+			return "heavy"
+		})},
+	}
+
+	if got := formatEntry(t, &DynamicFormatter{Pattern: "%message%"}, entry); got != "hello\n" {
+		t.Fatalf("got %q", got)
+	}
+	if calls != 0 {
+		t.Fatalf("lazy field evaluated %d times without a placeholder using it", calls)
+	}
+}
+
+func TestFunctionNameFormatters(t *testing.T) {
+	const name = "github.com/x/y/pkg.(*T).Method"
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"short", "Method"},
+		{"full", name},
+		{"package", "github.com/x/y/pkg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := functionNameFormatterFor(tt.format).Format(name); got != tt.want {
+				t.Fatalf("Format(%q) = %q, want %q", name, got, tt.want)
+			}
+		})
+	}
+}
+
+type prefixFunctionNameFormatter struct{}
+
+func (prefixFunctionNameFormatter) Format(fullName string) string {
+	return "fn:" + fullName
+}
+
+func TestRegisterFunctionNameFormatter(t *testing.T) {
+	old := GetFunctionNameFormatter()
+	RegisterFunctionNameFormatter(prefixFunctionNameFormatter{})
+	t.Cleanup(func() {
+		RegisterFunctionNameFormatter(old)
+	})
+
+	const name = "github.com/x/y/pkg.(*T).Method"
+	if got := functionNameFormatterFor("").Format(name); got != "fn:"+name {
+		t.Fatalf("got %q, want the registered formatter to be used", got)
+	}
+	if got := functionNameFormatterFor("full").Format(name); got != name {
+		t.Fatalf("got %q, want an explicit functionFormat to win", got)
+	}
+}
+
+func TestInitLogsFunctionName(t *testing.T) {
+	initTestLogger(t)
+	buf := captureOutput(t)
+	Log.Info("hello")
+
+	if got := buf.String(); !strings.Contains(got, "| formatter_test.go:") || !strings.Contains(got, "| TestInitLogsFunctionName | hello") {
+		t.Fatalf("unexpected caller in %q", got)
+	}
+}
+
+func TestFormatWithNilFormatters(t *testing.T) {
+	entry := &logrus.Entry{
+		Message: "hello",
+		Caller:  &runtime.Frame{File: "/src/app/main.go", Line: 12, Function: "github.com/x/y/pkg.(*T).Method"},
+	}
+	got := formatEntry(t, &DynamicFormatter{Pattern: "%file%:%line% %function% %message%"}, entry)
+	if got != "main.go:12 Method hello\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestTimestampTimeZone(t *testing.T) {
+	instant := time.Date(2024, 3, 10, 12, 34, 56, 789000000, time.UTC)
+	tests := []struct {
+		zone string
+		want string
+	}{
+		{"UTC", "2024-03-10T12:34:56.789Z"},
+		{"Asia/Ho_Chi_Minh", "2024-03-10T19:34:56.789+07:00"},
+		{"America/New_York", "2024-03-10T08:34:56.789-04:00"},
+		{"Not/AZone", "2024-03-10T12:34:56.789Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			resetLogger(t)
+			cfg := &LogConfig{Pattern: "%timestamp%", TimestampFormat: "2006-01-02T15:04:05.000Z07:00", TimeZone: tt.zone}
+			if err := InitWithOptions(WithLogConfig(cfg)); err != nil {
+				t.Fatal(err)
+			}
+			buf := captureOutput(t)
+			Log.WithTime(instant).Info("tick")
+
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholdersInValuesAreNotExpanded(t *testing.T) {
+	f := &DynamicFormatter{Pattern: "%level% | %message% | %path%"}
+	entry := &logrus.Entry{
+		Level:   logrus.InfoLevel,
+		Message: "literal %fields% and %level% in message",
+		Data:    logrus.Fields{"path": "/%message%", "secret": "s3cr3t"},
+	}
+
+	want := "INFO | literal %fields% and %level% in message | /%message%\n"
+	if got := formatEntry(t, f, entry); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestIsRedactedFollowsActiveFormatter(t *testing.T) {
+	resetLogger(t)
+	if IsRedacted("password") {
+		t.Fatal("nothing is redacted before Init")
+	}
+	if err := InitWithOptions(WithLogConfig(&LogConfig{RedactKeys: []string{"Password"}})); err != nil {
+		t.Fatal(err)
+	}
+	if !IsRedacted("password") || IsRedacted("user") {
+		t.Fatal("IsRedacted should match the configured keys case-insensitively")
+	}
+	SetFormatter(&DynamicFormatter{Pattern: "%message%", RedactKeys: []string{"token"}})
+	if IsRedacted("password") || !IsRedacted("TOKEN") {
+		t.Fatal("IsRedacted should follow SetFormatter")
+	}
+}
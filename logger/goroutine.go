@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"bytes"
+	"github.com/sirupsen/logrus"
+	"runtime"
+	"strconv"
+)
+
+// goroutineHook adds a goroutine field to every entry. Render it with
+// %goroutine% or %fields% in the pattern.
+type goroutineHook struct{}
+
+func (goroutineHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (goroutineHook) Fire(entry *logrus.Entry) error {
+	entry.Data["goroutine"] = goroutineID()
+	return nil
+}
+
+// goroutineID parses the ID from the "goroutine N [" header of runtime.Stack.
+// It costs a stack capture per call, so it is only enabled on request.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestGoroutineIDDistinctPerGoroutine(t *testing.T) {
+	resetLogger(t)
+	if err := InitWithOptions(WithLogConfig(&LogConfig{Pattern: "%message% %goroutine%", IncludeGoroutineID: true})); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Log.Info("from goroutine")
+		}()
+	}
+	wg.Wait()
+
+	ids := regexp.MustCompile(`from goroutine (\d+)\n`).FindAllStringSubmatch(buf.String(), -1)
+	if len(ids) != 2 {
+		t.Fatalf("expected two entries with a goroutine ID, got %q", buf.String())
+	}
+	if ids[0][1] == ids[1][1] || ids[0][1] == "0" {
+		t.Fatalf("goroutine IDs %s and %s should be distinct and non-zero", ids[0][1], ids[1][1])
+	}
+}
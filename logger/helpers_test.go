@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+)
+
+func resetLogger(t *testing.T) {
+	t.Helper()
+	initialized = false
+	Log = nil
+	registeredHooks = nil
+	activeFormatter.Store(nil)
+	includeDeadline.Store(false)
+	t.Cleanup(func() {
+		initialized = false
+		Log = nil
+		registeredHooks = nil
+		activeFormatter.Store(nil)
+		includeDeadline.Store(false)
+	})
+}
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	Log.SetOutput(&buf)
+	return &buf
+}
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	old, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(old)
+	})
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
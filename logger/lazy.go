@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type LazyValue struct {
+	fn func() any
+}
+
+func Lazy(fn func() any) LazyValue {
+	return LazyValue{fn: fn}
+}
+
+func (l LazyValue) Value() any {
+	if l.fn == nil {
+		return nil
+	}
+	return l.fn()
+}
+
+func (l LazyValue) String() string {
+	return fmt.Sprint(l.Value())
+}
+
+func (l LazyValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Value())
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+	"strings"
+	"testing"
+)
+
+func TestLazyNotCalledWhenLevelFiltered(t *testing.T) {
+	initWithPattern(t, "%message% %dump%")
+	buf := captureOutput(t)
+
+	calls := 0
+	dump := Lazy(func() any {
+		calls++
+		return "heavy"
+	})
+
+	Log.WithField("dump", dump).Debug("filtered")
+	if calls != 0 {
+		t.Fatalf("lazy value evaluated %d times for a filtered entry", calls)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+
+	Log.WithFields(logrus.Fields{"dump": dump}).Info("logged")
+	if calls != 1 {
+		t.Fatalf("lazy value evaluated %d times, want 1", calls)
+	}
+	if got := buf.String(); !strings.Contains(got, "logged heavy") {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
@@ -1,14 +1,18 @@
 package logger
 
 import (
+	"errors"
 	"github.com/sirupsen/logrus"
 	"os"
-	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var registeredMessageFormater MessageFormater = &DefaultMessageFormater{}
 var registeredDefaultFunctionNameFormatter FunctionNameFormatter = &DefaultFunctionNameFormatter{}
+var registeredHooks []logrus.Hook
+var hooksMu sync.Mutex
 
 func RegisterMessageFormater(m MessageFormater) {
 	registeredMessageFormater = m
@@ -17,6 +21,22 @@ func RegisterFunctionNameFormatter(m FunctionNameFormatter) {
 	registeredDefaultFunctionNameFormatter = m
 }
 
+// RegisterHook attaches hook to Log. It can be called before or after Init
+// and from any goroutine; hooks registered before Init are added when Log
+// is created.
+func RegisterHook(hook logrus.Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	registeredHooks = append(registeredHooks, hook)
+	if Log != nil {
+		Log.AddHook(hook)
+	}
+}
+
+func RegisterExitHook(hook func()) {
+	logrus.RegisterExitHandler(hook)
+}
+
 func GetMessageFormater() MessageFormater {
 	return registeredMessageFormater
 }
@@ -25,34 +45,178 @@ func GetFunctionNameFormatter() FunctionNameFormatter {
 	return registeredDefaultFunctionNameFormatter
 }
 
+const (
+	defaultTimestampFormat = "2006-01-02 15:04:05"
+	defaultPattern         = "%timestamp% | %level% | %requestId% | %file%:%line% | %function% | %message%"
+	defaultLevel           = "info"
+)
+
 var Log *logrus.Logger
-var userOnce sync.Once
+var initMu sync.Mutex
+var initialized bool
+var activeFormatter atomic.Pointer[DynamicFormatter]
+var includeDeadline atomic.Bool
 
-func Init() error {
-	userOnce.Do(func() {
+type Option func(*initOptions)
+
+type initOptions struct {
+	configPath   string
+	configFormat string
+	config       *LogConfig
+}
+
+var ErrAlreadyInitialized = errors.New("logger already initialized")
+
+func WithConfigPath(path string) Option {
+	return func(o *initOptions) {
+		o.configPath = path
+	}
+}
+
+// WithConfigFormat sets the format ("xml", "json" or "yaml") of the file
+// given to WithConfigPath instead of inferring it from the extension.
+func WithConfigFormat(format string) Option {
+	return func(o *initOptions) {
+		o.configFormat = format
+	}
+}
+
+func WithLogConfig(cfg *LogConfig) Option {
+	return func(o *initOptions) {
+		o.config = cfg
+	}
+}
+
+func (o *initOptions) loadConfig() (*LogConfig, error) {
+	switch {
+	case o.config != nil:
+		cfg := *o.config
+		return &cfg, nil
+	case o.configPath != "" && o.configFormat != "":
+		return loadLogConfigAs(o.configPath, o.configFormat)
+	case o.configPath != "":
+		return loadLogConfigFile(o.configPath)
+	default:
 		dir, _ := os.Getwd()
-		cfg, err := LoadLogConfig(filepath.Join(dir, "/log-config.xml"))
-		if err != nil {
-			cfg = &LogConfig{
-				TimestampFormat: "2006-01-02 15:04:05",
-				Pattern:         "%timestamp% | %level% | %requestId% | %file%:%line% | %function% | %message%",
-				Level:           "info",
-			}
+		return loadLogConfigFromDir(dir)
+	}
+}
+
+func Init() error {
+	return InitWithOptions()
+}
+
+func InitWithConfig(path string) error {
+	return InitWithOptions(WithConfigPath(path))
+}
+
+// InitWithOptions builds Log. Once it has succeeded, later calls without
+// options are no-ops and later calls with options return
+// ErrAlreadyInitialized because their config would be ignored. If an
+// explicit config path fails to load, Log is built from defaults and the
+// error is returned, but a later call may still initialize with a corrected
+// config.
+func InitWithOptions(opts ...Option) error {
+	o := &initOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	initMu.Lock()
+	defer initMu.Unlock()
+	if initialized {
+		if len(opts) > 0 {
+			return ErrAlreadyInitialized
 		}
+		return nil
+	}
 
-		level, err := logrus.ParseLevel(cfg.Level)
-		if err != nil {
-			level = logrus.InfoLevel
+	var initErr error
+	cfg, err := o.loadConfig()
+	if err != nil {
+		if o.configPath != "" {
+			initErr = err
 		}
-		Log = logrus.New()
-		Log.SetReportCaller(true)
-		Log.SetLevel(level)
-		Log.SetFormatter(&DynamicFormatter{
-			Pattern:               cfg.Pattern,
-			TimestampFormat:       cfg.TimestampFormat,
-			MsgFormatter:          GetMessageFormater(),
-			FunctionNameFormatter: GetFunctionNameFormatter(),
-		})
-	})
-	return nil
+		cfg = &LogConfig{}
+	}
+	cfg.applyDefaults()
+	patternErr := ValidatePattern(cfg.Pattern)
+	patternInvalid := patternUnusable(cfg.Pattern, patternErr)
+	if patternInvalid {
+		cfg.Pattern = defaultPattern
+	}
+	location, locationErr := loadLocation(cfg.TimeZone)
+	if locationErr != nil {
+		location = time.UTC
+	}
+
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	log := logrus.New()
+	log.SetReportCaller(true)
+	log.SetLevel(level)
+	setFormatter(log, newDynamicFormatter(cfg, location))
+	includeDeadline.Store(cfg.IncludeDeadline)
+	log.AddHook(panicCallerHook{})
+	if cfg.IncludeGoroutineID {
+		log.AddHook(goroutineHook{})
+	}
+	if hook := newStaticFieldsHook(cfg); hook != nil {
+		log.AddHook(hook)
+	}
+	hooksMu.Lock()
+	for _, hook := range registeredHooks {
+		log.AddHook(hook)
+	}
+	retry := Log != nil
+	Log = log
+	hooksMu.Unlock()
+	if !retry {
+		logrus.RegisterExitHandler(syncOutput)
+	}
+	if patternInvalid {
+		Log.Warnf("Invalid log pattern, falling back to default: %v", patternErr)
+	} else if patternErr != nil {
+		Log.Warnf("Log pattern uses fields that may be missing from entries: %v", patternErr)
+	}
+	if locationErr != nil {
+		Log.Warnf("Invalid time zone %q, falling back to UTC: %v", cfg.TimeZone, locationErr)
+	}
+	initialized = initErr == nil
+	return initErr
+}
+
+func newDynamicFormatter(cfg *LogConfig, location *time.Location) *DynamicFormatter {
+	return &DynamicFormatter{
+		Pattern:               cfg.Pattern,
+		TimestampFormat:       cfg.TimestampFormat,
+		MsgFormatter:          GetMessageFormater(),
+		FunctionNameFormatter: functionNameFormatterFor(cfg.FunctionFormat),
+		RedactKeys:            cfg.RedactKeys,
+		EscapeNewlines:        cfg.EscapeNewlines,
+		Location:              location,
+		MaxMessageBytes:       cfg.MaxMessageBytes,
+		MaxFieldBytes:         cfg.MaxFieldBytes,
+	}
+}
+
+// SetFormatter installs f on Log. Use it instead of Log.SetFormatter so that
+// RingBuffer, Recover and IsRedacted follow the new settings.
+func SetFormatter(f *DynamicFormatter) {
+	setFormatter(Log, f)
+}
+
+// setFormatter installs f on log and records it so hooks such as
+// RingBuffer can apply the same message formatting and redaction.
+func setFormatter(log *logrus.Logger, f *DynamicFormatter) {
+	log.SetFormatter(f)
+	activeFormatter.Store(f)
+}
+
+func syncOutput() {
+	if s, ok := Log.Out.(interface{ Sync() error }); ok {
+		_ = s.Sync()
+	}
 }
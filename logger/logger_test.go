@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+	"sync"
+	"testing"
+)
+
+type memoryHook struct {
+	mu      sync.Mutex
+	entries []*logrus.Entry
+}
+
+func (h *memoryHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *memoryHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func (h *memoryHook) levels() []logrus.Level {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	levels := make([]logrus.Level, 0, len(h.entries))
+	for _, e := range h.entries {
+		levels = append(levels, e.Level)
+	}
+	return levels
+}
+
+func TestRegisterHookBeforeAndAfterInit(t *testing.T) {
+	resetLogger(t)
+	before := &memoryHook{}
+	RegisterHook(before)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t)
+	after := &memoryHook{}
+	RegisterHook(after)
+
+	Log.Info("info")
+	Log.Warn("warn")
+	Log.Error("error")
+
+	want := []logrus.Level{logrus.InfoLevel, logrus.WarnLevel, logrus.ErrorLevel}
+	for name, hook := range map[string]*memoryHook{"before": before, "after": after} {
+		got := hook.levels()
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: got %v, want %v", name, got, want)
+			}
+		}
+	}
+	if msg := before.entries[0].Message; msg != "info" {
+		t.Fatalf("recorded message = %q, want %q", msg, "info")
+	}
+}
+
+func TestRegisterHookConcurrentWithInit(t *testing.T) {
+	resetLogger(t)
+	hook := &memoryHook{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterHook(hook)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Init()
+		}()
+	}
+	wg.Wait()
+	captureOutput(t)
+
+	Log.Info("once")
+	if got := len(hook.levels()); got != 10 {
+		t.Fatalf("hook fired %d times, want 10 (one per registration)", got)
+	}
+}
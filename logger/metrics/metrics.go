@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"github.com/kimxuanhong/go-logger/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+var messagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "log_messages_total",
+	Help: "Number of log messages emitted, by level.",
+}, []string{"level"})
+
+var registerOnce sync.Once
+
+type hook struct{}
+
+func (hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook) Fire(entry *logrus.Entry) error {
+	messagesTotal.WithLabelValues(entry.Level.String()).Inc()
+	return nil
+}
+
+func Register() {
+	registerOnce.Do(func() {
+		logger.RegisterHook(hook{})
+	})
+}
+
+func Collector() prometheus.Collector {
+	return messagesTotal
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/kimxuanhong/go-logger/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"os"
+	"testing"
+)
+
+func TestRegisterCountsMessagesByLevel(t *testing.T) {
+	if err := logger.Init(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	logger.Log.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.Log.SetOutput(os.Stderr)
+	})
+
+	Register()
+	Register()
+	warnBefore := testutil.ToFloat64(messagesTotal.WithLabelValues("warning"))
+	errorBefore := testutil.ToFloat64(messagesTotal.WithLabelValues("error"))
+
+	logger.Log.Warn("one")
+	logger.Log.Warn("two")
+	logger.Log.Error("three")
+	logger.Log.Debug("filtered")
+
+	if got := testutil.ToFloat64(messagesTotal.WithLabelValues("warning")) - warnBefore; got != 2 {
+		t.Fatalf("warning count grew by %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(messagesTotal.WithLabelValues("error")) - errorBefore; got != 1 {
+		t.Fatalf("error count grew by %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(messagesTotal.WithLabelValues("debug")); got != 0 {
+		t.Fatalf("debug count = %v, want 0 for a filtered level", got)
+	}
+}
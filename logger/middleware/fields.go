@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"github.com/kimxuanhong/go-logger/logger"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LoggedHeaders lists the request headers RequestFields includes, each as a
+// field named after the canonical header. None are logged by default.
+// Headers matching the logger's redactKeys are masked when the fields are
+// built.
+var LoggedHeaders []string
+
+func RequestFields(r *http.Request) map[string]any {
+	fields := map[string]any{
+		"method":     r.Method,
+		"path":       r.URL.Path,
+		"query":      r.URL.RawQuery,
+		"remoteAddr": r.RemoteAddr,
+		"userAgent":  r.UserAgent(),
+	}
+	for _, name := range LoggedHeaders {
+		values := r.Header.Values(name)
+		switch {
+		case len(values) == 0:
+		case logger.IsRedacted(name):
+			fields[http.CanonicalHeaderKey(name)] = logger.RedactedValue
+		default:
+			fields[http.CanonicalHeaderKey(name)] = strings.Join(values, ", ")
+		}
+	}
+	return fields
+}
+
+func ResponseFields(status int, size int64, d time.Duration) map[string]any {
+	return map[string]any{
+		"status":  status,
+		"bytes":   size,
+		"latency": d,
+	}
+}
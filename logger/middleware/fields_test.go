@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"github.com/kimxuanhong/go-logger/logger"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSyntheticRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/orders/42?dryRun=true", nil)
+	req.RemoteAddr = "10.0.0.7:5123"
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Tenant-Id", "acme")
+	return req
+}
+
+func TestRequestFields(t *testing.T) {
+	fields := RequestFields(newSyntheticRequest())
+	want := map[string]any{
+		"method":     http.MethodPut,
+		"path":       "/orders/42",
+		"query":      "dryRun=true",
+		"remoteAddr": "10.0.0.7:5123",
+		"userAgent":  "curl/8.0",
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %v, want %v", fields, want)
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Fatalf("%s = %v, want %v", k, fields[k], v)
+		}
+	}
+}
+
+type fieldsHook struct {
+	data logrus.Fields
+}
+
+func (h *fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *fieldsHook) Fire(entry *logrus.Entry) error {
+	h.data = entry.Data
+	return nil
+}
+
+func TestRequestFieldsLoggedHeadersAreRedacted(t *testing.T) {
+	buf := captureLogs(t)
+	old := logger.Log.Formatter.(*logger.DynamicFormatter)
+	logger.SetFormatter(&logger.DynamicFormatter{Pattern: "%message% %fields%", RedactKeys: []string{"Authorization"}})
+	oldHeaders := LoggedHeaders
+	LoggedHeaders = []string{"authorization", "X-Tenant-Id", "X-Missing"}
+	t.Cleanup(func() {
+		logger.SetFormatter(old)
+		LoggedHeaders = oldHeaders
+	})
+
+	fields := RequestFields(newSyntheticRequest())
+	if got := fields["Authorization"]; got != logger.RedactedValue {
+		t.Fatalf("Authorization = %v, want it masked in the returned map", got)
+	}
+	if fields["X-Tenant-Id"] != "acme" {
+		t.Fatalf("X-Tenant-Id = %v, want acme", fields["X-Tenant-Id"])
+	}
+	if _, ok := fields["X-Missing"]; ok {
+		t.Fatal("absent headers should be omitted")
+	}
+
+	hook := &fieldsHook{}
+	log := logrus.New()
+	log.SetOutput(buf)
+	log.AddHook(hook)
+	log.WithFields(fields).Info("request")
+	if got := hook.data["Authorization"]; got != logger.RedactedValue {
+		t.Fatalf("hook saw Authorization = %v, want it masked", got)
+	}
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("output leaked the token: %q", buf.String())
+	}
+}
+
+func TestResponseFields(t *testing.T) {
+	fields := ResponseFields(http.StatusCreated, 512, 150*time.Millisecond)
+	if fields["status"] != http.StatusCreated || fields["bytes"] != int64(512) || fields["latency"] != 150*time.Millisecond {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+}
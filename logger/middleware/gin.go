@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kimxuanhong/go-logger/logger"
+	"time"
+)
+
+func Gin(opts ...Option) gin.HandlerFunc {
+	o := newOptions(opts)
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID, header := o.requestID(c.GetHeader)
+		ctx := logger.InjectRequestID(c.Request.Context(), requestID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Set("requestId", requestID)
+		c.Header(header, requestID)
+
+		c.Next()
+
+		method := c.Request.Method
+		path := c.Request.URL.Path
+		status := c.Writer.Status()
+		latency := time.Since(start)
+		logger.WithContext(ctx).Infof("%s %s %d %s", method, path, status, latency)
+	}
+}
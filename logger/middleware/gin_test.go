@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kimxuanhong/go-logger/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGinReusesIncomingRequestID(t *testing.T) {
+	buf := captureLogs(t)
+	gin.SetMode(gin.TestMode)
+
+	var seen string
+	r := gin.New()
+	r.Use(Gin())
+	r.GET("/ping", func(c *gin.Context) {
+		seen = logger.GetRequestID(c.Request.Context())
+		c.String(http.StatusCreated, "pong")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(DefaultRequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if seen != "req-123" {
+		t.Fatalf("handler saw request ID %q, want req-123", seen)
+	}
+	if got := w.Header().Get(DefaultRequestIDHeader); got != "req-123" {
+		t.Fatalf("response header = %q, want req-123", got)
+	}
+	if out := buf.String(); !strings.Contains(out, "req-123") || !strings.Contains(out, "GET /ping 201") {
+		t.Fatalf("unexpected log output %q", out)
+	}
+}
+
+func TestGinGeneratesRequestIDWithOptions(t *testing.T) {
+	captureLogs(t)
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Gin(WithHeader("X-Trace"), WithIDGenerator(func() string { return "generated" })))
+	r.GET("/", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := w.Header().Get("X-Trace"); got != "generated" {
+		t.Fatalf("X-Trace = %q, want generated", got)
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"github.com/kimxuanhong/go-logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		requestID, header := o.requestIDFromMetadata(ctx)
+		ctx = logger.InjectRequestID(ctx, requestID)
+		_ = grpc.SetTrailer(ctx, metadata.Pairs(header, requestID))
+
+		resp, err := handler(ctx, req)
+
+		logRPC(ctx, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		requestID, header := o.requestIDFromMetadata(ss.Context())
+		ctx := logger.InjectRequestID(ss.Context(), requestID)
+		ss.SetTrailer(metadata.Pairs(header, requestID))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		logRPC(ctx, info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+func (o *options) requestIDFromMetadata(ctx context.Context) (id, header string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return o.requestID(func(header string) string {
+		if values := md.Get(header); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	})
+}
+
+func logRPC(ctx context.Context, method string, err error, latency time.Duration) {
+	code := status.Code(err)
+	logger.WithContext(ctx).Infof("%s %s %s", method, code, latency)
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
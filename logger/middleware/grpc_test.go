@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"github.com/kimxuanhong/go-logger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"strings"
+	"testing"
+)
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	seen string
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.seen = logger.GetRequestID(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func TestUnaryServerInterceptorPropagatesRequestID(t *testing.T) {
+	buf := captureLogs(t)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(UnaryServerInterceptor()))
+	health := &healthServer{}
+	grpc_health_v1.RegisterHealthServer(srv, health)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "rpc-42")
+	var trailer metadata.MD
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Trailer(&trailer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if health.seen != "rpc-42" {
+		t.Fatalf("handler saw request ID %q, want rpc-42", health.seen)
+	}
+	if got := trailer.Get("x-request-id"); len(got) != 1 || got[0] != "rpc-42" {
+		t.Fatalf("trailer x-request-id = %v, want [rpc-42]", got)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "rpc-42") || !strings.Contains(out, "/grpc.health.v1.Health/Check OK") {
+		t.Fatalf("unexpected log output %q", out)
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"bytes"
+	"github.com/kimxuanhong/go-logger/logger"
+	"os"
+	"testing"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	if err := logger.Init(); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	logger.Log.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.Log.SetOutput(os.Stderr)
+	})
+	return &buf
+}
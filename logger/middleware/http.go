@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"bufio"
+	"github.com/kimxuanhong/go-logger/logger"
+	"net"
+	"net/http"
+	"time"
+)
+
+func HTTP(next http.Handler, opts ...Option) http.Handler {
+	o := newOptions(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID, header := o.requestID(r.Header.Get)
+		ctx := logger.InjectRequestID(r.Context(), requestID)
+		w.Header().Set(header, requestID)
+
+		wrapped, rw := wrapResponseWriter(w)
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+		logger.WithContext(ctx).Infof("%s %s %d %s", r.Method, r.URL.Path, rw.status, time.Since(start))
+	})
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) flush() {
+	w.wroteHeader = true
+	w.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (w *responseWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+type flushWriter struct{ *responseWriter }
+
+func (w flushWriter) Flush() { w.flush() }
+
+type hijackWriter struct{ *responseWriter }
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return w.hijack() }
+
+type flushHijackWriter struct{ *responseWriter }
+
+func (w flushHijackWriter) Flush() { w.flush() }
+
+func (w flushHijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) { return w.hijack() }
+
+// wrapResponseWriter records the status code of w and exposes http.Flusher
+// and http.Hijacker only when w itself implements them.
+func wrapResponseWriter(w http.ResponseWriter) (http.ResponseWriter, *responseWriter) {
+	rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+	_, isFlusher := w.(http.Flusher)
+	_, isHijacker := w.(http.Hijacker)
+	switch {
+	case isFlusher && isHijacker:
+		return flushHijackWriter{rw}, rw
+	case isFlusher:
+		return flushWriter{rw}, rw
+	case isHijacker:
+		return hijackWriter{rw}, rw
+	default:
+		return rw, rw
+	}
+}
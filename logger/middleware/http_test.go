@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bufio"
+	"github.com/kimxuanhong/go-logger/logger"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRequestIDRoundTrip(t *testing.T) {
+	buf := captureLogs(t)
+
+	var seen string
+	h := HTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.GetRequestID(r.Context())
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/brew", nil)
+	req.Header.Set(DefaultRequestIDHeader, "abc-1")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if seen != "abc-1" {
+		t.Fatalf("handler saw request ID %q, want abc-1", seen)
+	}
+	if got := w.Header().Get(DefaultRequestIDHeader); got != "abc-1" {
+		t.Fatalf("response header = %q, want abc-1", got)
+	}
+	if out := buf.String(); !strings.Contains(out, "abc-1") || !strings.Contains(out, "POST /brew 418") {
+		t.Fatalf("unexpected log output %q", out)
+	}
+}
+
+type plainWriter struct {
+	header http.Header
+}
+
+func (w *plainWriter) Header() http.Header         { return w.header }
+func (w *plainWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *plainWriter) WriteHeader(int)             {}
+
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func TestHTTPExposesOnlySupportedInterfaces(t *testing.T) {
+	captureLogs(t)
+	tests := []struct {
+		name         string
+		writer       http.ResponseWriter
+		wantFlusher  bool
+		wantHijacker bool
+	}{
+		{"plain", &plainWriter{header: http.Header{}}, false, false},
+		{"flusher", httptest.NewRecorder(), true, false},
+		{"flusher and hijacker", hijackableRecorder{httptest.NewRecorder()}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var isFlusher, isHijacker bool
+			h := HTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, isFlusher = w.(http.Flusher)
+				_, isHijacker = w.(http.Hijacker)
+			}))
+			h.ServeHTTP(tt.writer, httptest.NewRequest(http.MethodGet, "/", nil))
+			if isFlusher != tt.wantFlusher || isHijacker != tt.wantHijacker {
+				t.Fatalf("Flusher=%v Hijacker=%v, want %v %v", isFlusher, isHijacker, tt.wantFlusher, tt.wantHijacker)
+			}
+		})
+	}
+}
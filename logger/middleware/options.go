@@ -0,0 +1,56 @@
+package middleware
+
+import "github.com/google/uuid"
+
+const DefaultRequestIDHeader = "X-Request-ID"
+
+var GenerateRequestID = uuid.NewString
+
+type Option func(*options)
+
+type options struct {
+	headers    []string
+	generateID func() string
+}
+
+func WithHeader(name string) Option {
+	return func(o *options) {
+		o.headers = []string{name}
+	}
+}
+
+func WithHeaders(names ...string) Option {
+	return func(o *options) {
+		if len(names) > 0 {
+			o.headers = names
+		}
+	}
+}
+
+func WithIDGenerator(fn func() string) Option {
+	return func(o *options) {
+		o.generateID = fn
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		headers:    []string{DefaultRequestIDHeader},
+		generateID: GenerateRequestID,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// requestID returns the first non-empty configured header value and the
+// header it came from, or a generated ID and the first configured header.
+func (o *options) requestID(get func(header string) string) (id, header string) {
+	for _, h := range o.headers {
+		if id := get(h); id != "" {
+			return id, h
+		}
+	}
+	return o.generateID(), o.headers[0]
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"github.com/kimxuanhong/go-logger/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveRequestID(t *testing.T, header http.Header, opts ...Option) (seen string, resp http.Header) {
+	t.Helper()
+	h := HTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.GetRequestID(r.Context())
+	}), opts...)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return seen, w.Header()
+}
+
+func TestRequestIDReusesIncomingHeader(t *testing.T) {
+	captureLogs(t)
+	header := http.Header{}
+	header.Set("X-Request-ID", "from-client")
+
+	seen, resp := serveRequestID(t, header, WithHeaders("X-Correlation-ID", "X-Request-ID"))
+	if seen != "from-client" {
+		t.Fatalf("request ID = %q, want from-client", seen)
+	}
+	if got := resp.Get("X-Request-ID"); got != "from-client" {
+		t.Fatalf("response header = %q, want from-client echoed on X-Request-ID", got)
+	}
+	if got := resp.Get("X-Correlation-ID"); got != "" {
+		t.Fatalf("X-Correlation-ID = %q, want it unset when the ID came from X-Request-ID", got)
+	}
+}
+
+func TestRequestIDGeneratedWhenMissing(t *testing.T) {
+	captureLogs(t)
+	first, resp := serveRequestID(t, nil)
+	second, _ := serveRequestID(t, nil)
+	if first == "" || first == "null" || first == second {
+		t.Fatalf("generated IDs %q and %q should be distinct and non-empty", first, second)
+	}
+	if got := resp.Get(DefaultRequestIDHeader); got != first {
+		t.Fatalf("response header = %q, want %q", got, first)
+	}
+}
+
+func TestRequestIDCustomGenerator(t *testing.T) {
+	captureLogs(t)
+	seen, resp := serveRequestID(t, nil, WithHeader("X-Trace"), WithIDGenerator(func() string { return "fixed-id" }))
+	if seen != "fixed-id" {
+		t.Fatalf("request ID = %q, want fixed-id", seen)
+	}
+	if got := resp.Get("X-Trace"); got != "fixed-id" {
+		t.Fatalf("response header = %q, want fixed-id", got)
+	}
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+type panicFrameKey struct{}
+
+func Recover() {
+	if r := recover(); r != nil {
+		logPanic(r, false)
+	}
+}
+
+func RecoverAndPanic() {
+	if r := recover(); r != nil {
+		logPanic(r, false)
+		panic(r)
+	}
+}
+
+func RecoverAndExit() {
+	if r := recover(); r != nil {
+		logPanic(r, true)
+	}
+}
+
+// logPanic logs r with the stack of the panicking goroutine, starting at the
+// frame that panicked. The stack is appended to the message unless the
+// active pattern renders %stack% itself, and the value is only added as a
+// field for patterns that render %panic%, so %fields% does not repeat them.
+func logPanic(r any, exit bool) {
+	if Log == nil {
+		_ = Init()
+	}
+	stack := panicStack()
+	fields := logrus.Fields{}
+	if patternUses("panic") {
+		fields["panic"] = r
+	}
+	message := fmt.Sprintf("Recovered from panic: %v", r)
+	if patternUses("stack") {
+		fields["stack"] = stack
+	} else {
+		message += "\n" + stack
+	}
+
+	entry := Log.WithFields(fields)
+	if frame, ok := panicFrame(); ok {
+		entry = entry.WithContext(context.WithValue(context.Background(), panicFrameKey{}, &frame))
+	}
+	if exit {
+		entry.Fatal(message)
+	} else {
+		entry.Error(message)
+	}
+}
+
+func patternUses(placeholder string) bool {
+	f := activeFormatter.Load()
+	return f != nil && strings.Contains(f.Pattern, "%"+placeholder+"%")
+}
+
+// panicStack returns debug.Stack without the frames of the recovery path:
+// everything up to and including runtime.gopanic and the runtime frames
+// that raised the panic.
+func panicStack() string {
+	stack := strings.TrimSuffix(string(debug.Stack()), "\n")
+	header, frames, _ := strings.Cut(stack, "\n")
+	lines := strings.Split(frames, "\n")
+	for i := 0; i+1 < len(lines); i += 2 {
+		if !strings.HasPrefix(lines[i], "panic(") {
+			continue
+		}
+		rest := lines[i+2:]
+		for len(rest) >= 2 && strings.HasPrefix(rest[0], "runtime.") {
+			rest = rest[2:]
+		}
+		return header + "\n" + strings.Join(rest, "\n")
+	}
+	return stack
+}
+
+func panicFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	panicking := false
+	for {
+		frame, more := frames.Next()
+		if panicking && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame, true
+		}
+		if frame.Function == "runtime.gopanic" {
+			panicking = true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// panicCallerHook reports the panicking frame as the caller of entries
+// logged by logPanic instead of the deferred Recover call.
+type panicCallerHook struct{}
+
+func (panicCallerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (panicCallerHook) Fire(entry *logrus.Entry) error {
+	if entry.Caller == nil || entry.Context == nil {
+		return nil
+	}
+	if frame, ok := entry.Context.Value(panicFrameKey{}).(*runtime.Frame); ok {
+		entry.Caller = frame
+	}
+	return nil
+}
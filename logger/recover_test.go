@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var explodeLine int
+
+func explode() {
+	_, _, explodeLine, _ = runtime.Caller(0)
+	panic("boom")
+}
+
+func TestRecoverLogsValueStackAndCaller(t *testing.T) {
+	initTestLogger(t)
+	buf := captureOutput(t)
+
+	func() {
+		defer Recover()
+		explode()
+	}()
+
+	out := buf.String()
+	first, stack, _ := strings.Cut(out, "\n")
+	if want := fmt.Sprintf("| recover_test.go:%d | explode | Recovered from panic: boom", explodeLine+1); !strings.Contains(first, "| ERROR |") || !strings.Contains(first, want) {
+		t.Fatalf("first line = %q, want it to contain %q", first, want)
+	}
+	if !strings.HasPrefix(stack, "goroutine ") || !strings.Contains(stack, "logger.explode(") {
+		t.Fatalf("stack does not start at the panicking frame: %q", stack)
+	}
+	for _, frame := range []string{"runtime/debug.Stack", "logger.Recover", "runtime.gopanic", "panic("} {
+		if strings.Contains(stack, frame) {
+			t.Fatalf("stack should be trimmed past %s: %q", frame, stack)
+		}
+	}
+}
+
+func TestRecoverUsesStackPlaceholder(t *testing.T) {
+	resetLogger(t)
+	if err := InitWithOptions(WithLogConfig(&LogConfig{Pattern: "%message% | %panic% | %stack%", EscapeNewlines: true})); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+
+	func() {
+		defer Recover()
+		explode()
+	}()
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "Recovered from panic: boom | boom | goroutine ") || strings.Count(out, "\n") != 1 {
+		t.Fatalf("unexpected output %q", out)
+	}
+}
+
+func TestRecoverAndPanicRepanics(t *testing.T) {
+	initTestLogger(t)
+	buf := captureOutput(t)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+		if !strings.Contains(buf.String(), "Recovered from panic: boom") {
+			t.Fatalf("panic not logged: %q", buf.String())
+		}
+	}()
+	func() {
+		defer RecoverAndPanic()
+		explode()
+	}()
+}
+
+func TestRecoverInitializesLog(t *testing.T) {
+	resetLogger(t)
+	func() {
+		defer Recover()
+		explode()
+	}()
+	if Log == nil {
+		t.Fatal("Recover should initialize Log when Init was not called")
+	}
+}
+
+func TestRecoverRuntimeErrorSkipsRuntimeFrames(t *testing.T) {
+	initTestLogger(t)
+	buf := captureOutput(t)
+
+	func() {
+		defer Recover()
+		var m map[string]int
+		m["x"] = 1
+	}()
+
+	first, stack, _ := strings.Cut(buf.String(), "\n")
+	if !strings.Contains(first, "| recover_test.go:") || !strings.Contains(first, "assignment to entry in nil map") {
+		t.Fatalf("unexpected first line %q", first)
+	}
+	if strings.Contains(stack, "runtime.") {
+		t.Fatalf("stack should start at the panicking frame: %q", stack)
+	}
+}
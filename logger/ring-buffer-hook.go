@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+type RingBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+}
+
+func RingBufferHook(size int) *RingBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &RingBuffer{entries: make([]Entry, size)}
+}
+
+func (r *RingBuffer) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *RingBuffer) Fire(entry *logrus.Entry) error {
+	f := activeFormatter.Load()
+	if f == nil {
+		f = &DynamicFormatter{}
+	}
+	msgFormatter := f.MsgFormatter
+	if msgFormatter == nil {
+		msgFormatter = GetMessageFormater()
+	}
+
+	fields := make(map[string]any, len(entry.Data))
+	for k, v := range entry.Data {
+		if f.isRedacted(k) {
+			fields[k] = RedactedValue
+			continue
+		}
+		if lazy, ok := v.(LazyValue); ok {
+			v = lazy.Value()
+		}
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		fields[k] = v
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = Entry{
+		Time:    entry.Time,
+		Level:   entry.Level.String(),
+		Message: msgFormatter.Format(entry.Message),
+		Fields:  fields,
+	}
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+func (r *RingBuffer) Recent() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recent := make([]Entry, 0, len(r.entries))
+	if r.full {
+		recent = append(recent, r.entries[r.next:]...)
+	}
+	return append(recent, r.entries[:r.next]...)
+}
+
+func (r *RingBuffer) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r.Recent())
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type upperMessageFormater struct{}
+
+func (upperMessageFormater) Format(message string) string {
+	return strings.ToUpper(message)
+}
+
+func initRingBuffer(t *testing.T, size int, config string) *RingBuffer {
+	t.Helper()
+	resetLogger(t)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "log-config.json"), config)
+	initInDir(t, dir)
+	captureOutput(t)
+	ring := RingBufferHook(size)
+	RegisterHook(ring)
+	return ring
+}
+
+func TestRingBufferKeepsLastEntriesPastCapacity(t *testing.T) {
+	ring := initRingBuffer(t, 3, `{}`)
+	for i := 1; i <= 5; i++ {
+		Log.Infof("message %d", i)
+	}
+
+	recent := ring.Recent()
+	if len(recent) != 3 {
+		t.Fatalf("got %d entries, want 3", len(recent))
+	}
+	for i, e := range recent {
+		if want := fmt.Sprintf("message %d", i+3); e.Message != want {
+			t.Fatalf("entry %d = %q, want %q", i, e.Message, want)
+		}
+	}
+}
+
+func TestRingBufferAppliesFormattingAndRedaction(t *testing.T) {
+	old := GetMessageFormater()
+	RegisterMessageFormater(upperMessageFormater{})
+	t.Cleanup(func() {
+		RegisterMessageFormater(old)
+	})
+	ring := initRingBuffer(t, 4, `{"redactKeys": ["password"]}`)
+
+	Log.WithFields(logrus.Fields{
+		"Password": "hunter2",
+		"err":      errors.New("boom"),
+		"dump":     Lazy(func() any { return 42 }),
+	}).Warn("login failed")
+
+	recent := ring.Recent()
+	if len(recent) != 1 {
+		t.Fatalf("got %d entries, want 1", len(recent))
+	}
+	e := recent[0]
+	if e.Message != "LOGIN FAILED" {
+		t.Fatalf("message = %q, want the message formatter applied", e.Message)
+	}
+	if got := e.Fields["Password"]; got != RedactedValue {
+		t.Fatalf("Password = %v, want redacted", got)
+	}
+	if got := e.Fields["err"]; got != "boom" {
+		t.Fatalf("err = %v, want boom", got)
+	}
+	if got := e.Fields["dump"]; got != 42 {
+		t.Fatalf("dump = %v, want the resolved lazy value", got)
+	}
+
+	w := httptest.NewRecorder()
+	ring.Handler()(w, httptest.NewRequest(http.MethodGet, "/logs", nil))
+	var served []Entry
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatal(err)
+	}
+	if len(served) != 1 || served[0].Fields["dump"] != float64(42) {
+		t.Fatalf("unexpected handler output %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "hunter2") {
+		t.Fatalf("handler leaked a redacted value: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+// staticFieldsHook adds the hostname, pid and service fields resolved once at
+// Init. Render them with %hostname%, %pid%, %service% or %fields%.
+type staticFieldsHook struct {
+	fields logrus.Fields
+}
+
+func newStaticFieldsHook(cfg *LogConfig) *staticFieldsHook {
+	fields := logrus.Fields{}
+	if cfg.IncludeHostname {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "null"
+		}
+		fields["hostname"] = hostname
+	}
+	if cfg.IncludePID {
+		fields["pid"] = os.Getpid()
+	}
+	if cfg.ServiceName != "" {
+		fields["service"] = cfg.ServiceName
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &staticFieldsHook{fields: fields}
+}
+
+func (h *staticFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *staticFieldsHook) Fire(entry *logrus.Entry) error {
+	for k, v := range h.fields {
+		if _, ok := entry.Data[k]; !ok {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
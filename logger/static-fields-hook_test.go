@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStaticFieldsPresentAndStable(t *testing.T) {
+	resetLogger(t)
+	cfg := &LogConfig{Pattern: "%message% %fields%", IncludeHostname: true, IncludePID: true, ServiceName: "billing"}
+	if err := InitWithOptions(WithLogConfig(cfg)); err != nil {
+		t.Fatal(err)
+	}
+	buf := captureOutput(t)
+	Log.Info("first")
+	Log.WithField("service", "override").Warn("second")
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	static := fmt.Sprintf("hostname=%s pid=%d", hostname, os.Getpid())
+	if lines[0] != "first "+static+" service=billing" {
+		t.Fatalf("first line = %q", lines[0])
+	}
+	if lines[1] != "second "+static+" service=override" {
+		t.Fatalf("second line = %q, want the same static fields and the explicit service kept", lines[1])
+	}
+}
+
+func TestStaticFieldsHookDisabledByDefault(t *testing.T) {
+	if hook := newStaticFieldsHook(&LogConfig{}); hook != nil {
+		t.Fatalf("expected no hook without static fields, got %+v", hook)
+	}
+}
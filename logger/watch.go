@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultWatchInterval = time.Second
+
+// WatchConfig polls path every interval and applies the level, pattern and
+// formatting options of a changed config to Log. A non-positive interval
+// falls back to one second. Calling stop ends the polling and waits for an
+// in-flight reload to finish. Changes seen before Init are applied once Log
+// exists. The goroutine, hostname, pid and service fields are installed as
+// hooks by Init and are not changed by a reload.
+func WatchConfig(path string, interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	var once sync.Once
+
+	var lastMod time.Time
+	var lastSize int64
+	if info, err := os.Stat(path); err == nil {
+		lastMod, lastSize = info.ModTime(), info.Size()
+	}
+
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil || (info.ModTime().Equal(lastMod) && info.Size() == lastSize) {
+					continue
+				}
+				log := currentLog()
+				if log == nil {
+					continue
+				}
+				lastMod, lastSize = info.ModTime(), info.Size()
+				if err := reloadLogConfig(log, path); err != nil {
+					log.Warnf("Ignoring invalid log config %s: %v", path, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+		<-exited
+	}
+}
+
+// currentLog returns Log, synchronized with its assignment in Init.
+func currentLog() *logrus.Logger {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	return Log
+}
+
+func reloadLogConfig(log *logrus.Logger, path string) error {
+	cfg, err := loadLogConfigFile(path)
+	if err != nil {
+		return err
+	}
+	cfg.applyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	level, _ := logrus.ParseLevel(cfg.Level)
+	location, _ := loadLocation(cfg.TimeZone)
+	log.SetLevel(level)
+	setFormatter(log, newDynamicFormatter(cfg, location))
+	includeDeadline.Store(cfg.IncludeDeadline)
+	if err := ValidatePattern(cfg.Pattern); err != nil {
+		log.Warnf("Log pattern uses fields that may be missing from entries: %v", err)
+	}
+	return nil
+}
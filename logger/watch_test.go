@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchConfigAppliesLevelChange(t *testing.T) {
+	resetLogger(t)
+	path := filepath.Join(t.TempDir(), "log-config.json")
+	writeFile(t, path, `{"level": "info"}`)
+	initInDir(t, filepath.Dir(path))
+	captureOutput(t)
+
+	stop := WatchConfig(path, 10*time.Millisecond)
+	defer stop()
+
+	writeFile(t, path, `{"level": "debug"}`)
+	waitFor(t, "the debug level", func() bool {
+		return Log.GetLevel() == logrus.DebugLevel
+	})
+}
+
+func initInDir(t *testing.T, dir string) {
+	t.Helper()
+	chdir(t, dir)
+	if err := Init(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatchConfigIgnoresInvalidConfig(t *testing.T) {
+	resetLogger(t)
+	path := filepath.Join(t.TempDir(), "log-config.json")
+	writeFile(t, path, `{"level": "warn"}`)
+	initInDir(t, filepath.Dir(path))
+	out := &lockedBuffer{}
+	Log.SetOutput(out)
+
+	stop := WatchConfig(path, 10*time.Millisecond)
+	defer stop()
+
+	writeFile(t, path, `{"level": "not-a-level"}`)
+	waitFor(t, "the invalid config warning", func() bool {
+		return strings.Contains(out.String(), "Ignoring invalid log config")
+	})
+	if Log.GetLevel() != logrus.WarnLevel {
+		t.Fatalf("level = %v, want warn", Log.GetLevel())
+	}
+}
+
+func TestWatchConfigAppliesChangeSeenBeforeInit(t *testing.T) {
+	resetLogger(t)
+	path := filepath.Join(t.TempDir(), "log-config.json")
+	writeFile(t, path, `{"level": "info"}`)
+
+	stop := WatchConfig(path, 10*time.Millisecond)
+	defer stop()
+
+	writeFile(t, path, `{"level": "error"}`)
+	time.Sleep(50 * time.Millisecond)
+	initInDir(t, t.TempDir())
+	waitFor(t, "the pending reload", func() bool {
+		return currentLog().GetLevel() == logrus.ErrorLevel
+	})
+}
+
+func TestWatchConfigNonPositiveInterval(t *testing.T) {
+	stop := WatchConfig(filepath.Join(t.TempDir(), "missing.json"), 0)
+	stop()
+	stop()
+}